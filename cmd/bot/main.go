@@ -8,25 +8,36 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/giovannigabriele/go-todo-bot/internal/config"
 	"github.com/giovannigabriele/go-todo-bot/internal/health"
 	"github.com/giovannigabriele/go-todo-bot/internal/llm"
+	"github.com/giovannigabriele/go-todo-bot/internal/logging"
+	"github.com/giovannigabriele/go-todo-bot/internal/notify"
 	"github.com/giovannigabriele/go-todo-bot/internal/queue"
 	"github.com/giovannigabriele/go-todo-bot/internal/sheets"
 	"github.com/giovannigabriele/go-todo-bot/internal/telegram"
+	"github.com/giovannigabriele/go-todo-bot/internal/web"
+)
+
+const (
+	// maxCatchUpBacklog bounds how many missed updates a restart will
+	// replay before giving up and entering normal long-polling.
+	maxCatchUpBacklog = 500
+	// catchUpMessageTimeout bounds how long processing a single missed
+	// update during catch-up may take.
+	catchUpMessageTimeout = 10 * time.Second
 )
 
 func main() {
 	// Configure logging
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339})
-	if os.Getenv("DEBUG") == "true" {
-		zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	} else {
-		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	logger, err := logging.New()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure logging")
 	}
+	log.Logger = logger.Logger
+	defer logger.Close()
 
 	log.Info().Msg("Starting TODO Bot")
 
@@ -49,6 +60,22 @@ func main() {
 	}
 	defer queueManager.Close()
 
+	// Build the composite notifier so todo confirmations, sheet-sync
+	// failures, and batch summaries can reach operators over Slack or
+	// email even without a Telegram notify chat. Only the Telegram
+	// handler sends through it; in web-only mode nothing is notified.
+	var notifiers []notify.Notifier
+	if cfg.TelegramToken != "" && cfg.TelegramNotifyChatID != "" {
+		notifiers = append(notifiers, notify.NewTelegramNotifier(cfg.TelegramToken, cfg.TelegramNotifyChatID))
+	}
+	if cfg.SlackWebhookURL != "" {
+		notifiers = append(notifiers, notify.NewSlackNotifier(cfg.SlackWebhookURL))
+	}
+	if cfg.SMTPHost != "" && cfg.SMTPUser != "" && len(cfg.SMTPTo) > 0 {
+		notifiers = append(notifiers, notify.NewEmailNotifier(cfg.SMTPHost, cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPFrom, cfg.SMTPTo))
+	}
+	notifier := notify.NewComposite(notifiers...)
+
 	// Create batch-capable Telegram handler only if token is provided and valid
 	var handler *telegram.BatchHandler
 	if cfg.TelegramToken != "" {
@@ -56,7 +83,7 @@ func main() {
 		if err := telegram.ValidateTelegramToken(cfg.TelegramToken); err != nil {
 			log.Warn().Err(err).Msg("Telegram token validation failed - running in web-only mode")
 		} else {
-			handler, err = telegram.NewBatchHandler(cfg.TelegramToken, llmClient, sheetsClient, queueManager)
+			handler, err = telegram.NewBatchHandler(cfg.TelegramToken, llmClient, sheetsClient, queueManager, notifier, cfg.TOTPSecret)
 			if err != nil {
 				log.Warn().Err(err).Msg("Failed to create Telegram handler - running in web-only mode")
 			} else {
@@ -75,10 +102,13 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Start health check server
+	// Start health check and admin API server
+	webServer := web.NewServer(queueManager, sheetsClient, llmClient, cfg.AdminUser, cfg.AdminPassword)
+
 	go func() {
 		mux := http.NewServeMux()
 		mux.HandleFunc("/healthz", health.Handler())
+		mux.Handle("/api/", webServer.Handler())
 
 		port := os.Getenv("PORT")
 		if port == "" {
@@ -104,6 +134,11 @@ func main() {
 
 	// Start the bot only if handler exists
 	if handler != nil {
+		log.Info().Msg("Recovering missed updates...")
+		if err := handler.Recover(ctx, maxCatchUpBacklog, catchUpMessageTimeout); err != nil {
+			log.Error().Err(err).Msg("Catch-up recovery failed - continuing with long-polling")
+		}
+
 		log.Info().Msg("Starting TODO bot...")
 		if err := handler.Start(ctx); err != nil && err != context.Canceled {
 			log.Fatal().Err(err).Msg("Bot error")
@@ -116,24 +151,3 @@ func main() {
 
 	log.Info().Msg("Bot shutdown complete")
 }
-
-// setupLogging configures the logger
-func setupLogging() {
-	// Configure zerolog
-	zerolog.TimeFieldFormat = time.RFC3339
-
-	// Use console writer for development
-	log.Logger = log.Output(zerolog.ConsoleWriter{
-		Out:        os.Stdout,
-		TimeFormat: "15:04:05",
-	})
-
-	// Set log level based on environment
-	if os.Getenv("ENVIRONMENT") == "production" {
-		zerolog.SetGlobalLevel(zerolog.InfoLevel)
-	} else {
-		zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	}
-
-	log.Info().Msg("Logging configured")
-}
@@ -0,0 +1,88 @@
+// Package config loads runtime configuration for the TODO bot from
+// environment variables.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Config holds the settings needed to wire up the bot's dependencies.
+type Config struct {
+	TelegramToken    string
+	OpenRouterAPIKey string
+	GoogleScriptURL  string
+	DatabasePath     string
+
+	// AdminUser and AdminPassword gate the admin HTTP API behind HTTP
+	// Basic Auth. They must both be set or both be empty; when both are
+	// empty Basic Auth is not enforced and the admin routes are served
+	// without credentials. Load rejects a config that sets exactly one of
+	// them.
+	AdminUser     string
+	AdminPassword string
+
+	// SlackWebhookURL, when set, enables Slack notifications.
+	SlackWebhookURL string
+
+	// TelegramNotifyChatID, when set alongside TelegramToken, enables
+	// Telegram notifications to this fixed chat. They are sent by a
+	// separate notifier, not through the bot's long-polling handler, but
+	// only events raised by that handler are notified.
+	TelegramNotifyChatID string
+
+	// SMTP* fields configure email notifications, which are enabled when
+	// SMTPHost, SMTPUser and SMTPTo are set.
+	SMTPHost     string
+	SMTPUser     string
+	SMTPPassword string
+	SMTPFrom     string
+	SMTPTo       []string
+
+	// TOTPSecret gates destructive Telegram commands behind a second
+	// factor. When empty, BatchHandler generates a fresh one at startup
+	// and writes its provisioning QR code to disk; the generated secret
+	// is not stored, so a new one is generated on every start until
+	// TOTP_SECRET is set.
+	TOTPSecret string
+}
+
+// Load reads configuration from the environment, applying sane defaults
+// where possible and failing fast when a required value is missing.
+func Load() (*Config, error) {
+	cfg := &Config{
+		TelegramToken:        os.Getenv("TELEGRAM_TOKEN"),
+		OpenRouterAPIKey:     os.Getenv("OPENROUTER_API_KEY"),
+		GoogleScriptURL:      os.Getenv("GOOGLE_SCRIPT_URL"),
+		DatabasePath:         os.Getenv("DATABASE_PATH"),
+		AdminUser:            os.Getenv("ADMIN_USER"),
+		AdminPassword:        os.Getenv("ADMIN_PASSWORD"),
+		SlackWebhookURL:      os.Getenv("SLACK_WEBHOOK_URL"),
+		TelegramNotifyChatID: os.Getenv("TELEGRAM_NOTIFY_CHAT_ID"),
+		SMTPHost:             os.Getenv("SMTP_HOST"),
+		SMTPUser:             os.Getenv("SMTP_USER"),
+		SMTPPassword:         os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:             os.Getenv("SMTP_FROM"),
+		TOTPSecret:           os.Getenv("TOTP_SECRET"),
+	}
+	if to := os.Getenv("SMTP_TO"); to != "" {
+		cfg.SMTPTo = strings.Split(to, ",")
+	}
+
+	if cfg.DatabasePath == "" {
+		cfg.DatabasePath = "todo.db"
+	}
+
+	if cfg.OpenRouterAPIKey == "" {
+		return nil, fmt.Errorf("OPENROUTER_API_KEY is required")
+	}
+	if cfg.GoogleScriptURL == "" {
+		return nil, fmt.Errorf("GOOGLE_SCRIPT_URL is required")
+	}
+	if (cfg.AdminUser == "") != (cfg.AdminPassword == "") {
+		return nil, fmt.Errorf("ADMIN_USER and ADMIN_PASSWORD must both be set or both be empty")
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,12 @@
+// Package health provides the bot's liveness check endpoint.
+package health
+
+import "net/http"
+
+// Handler returns an http.HandlerFunc that reports the service as healthy.
+func Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}
+}
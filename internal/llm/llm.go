@@ -0,0 +1,145 @@
+// Package llm talks to the OpenRouter API to turn free-form chat messages
+// into structured todo items.
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// openRouterURL is the OpenRouter chat completions endpoint; overridable
+// in tests.
+var openRouterURL = "https://openrouter.ai/api/v1/chat/completions"
+
+const parsePrompt = `Extract todo items from the user's message. Respond with
+a JSON array of objects, each with "text" and optionally "due_date" and
+"priority" fields. Respond with the array only, no surrounding text.`
+
+// ParsedTodo is a single todo extracted from a user message.
+type ParsedTodo struct {
+	Text     string `json:"text"`
+	DueDate  string `json:"due_date,omitempty"`
+	Priority string `json:"priority,omitempty"`
+}
+
+// ParseRecord captures one parse request/response for later inspection.
+type ParseRecord struct {
+	ChatID    int64        `json:"chat_id"`
+	Input     string       `json:"input"`
+	Todos     []ParsedTodo `json:"todos"`
+	Err       string       `json:"error,omitempty"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// Client is a small wrapper around the OpenRouter chat completions API.
+type Client struct {
+	apiKey     string
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	history []ParseRecord
+}
+
+// NewClient creates a Client authenticated with the given OpenRouter API key.
+func NewClient(apiKey string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Parse sends message to the OpenRouter chat completions API and
+// extracts structured todos from it, recording the attempt (success or
+// failure) in History.
+func (c *Client) Parse(ctx context.Context, chatID int64, message string) ([]ParsedTodo, error) {
+	todos, err := c.parse(ctx, message)
+
+	rec := ParseRecord{ChatID: chatID, Input: message, Todos: todos}
+	if err != nil {
+		rec.Err = err.Error()
+	}
+	c.record(rec)
+
+	return todos, err
+}
+
+func (c *Client) parse(ctx context.Context, message string) ([]ParsedTodo, error) {
+	reqBody, err := json.Marshal(map[string]any{
+		"model": "openai/gpt-4o-mini",
+		"messages": []map[string]string{
+			{"role": "system", "content": parsePrompt},
+			{"role": "user", "content": message},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal parse request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, openRouterURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("build parse request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("call openrouter: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("openrouter returned status %d", resp.StatusCode)
+	}
+
+	var completion struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return nil, fmt.Errorf("decode openrouter response: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return nil, fmt.Errorf("openrouter returned no choices")
+	}
+
+	var todos []ParsedTodo
+	if err := json.Unmarshal([]byte(completion.Choices[0].Message.Content), &todos); err != nil {
+		return nil, fmt.Errorf("parse todos from completion: %w", err)
+	}
+	return todos, nil
+}
+
+// History returns the most recent parse records, newest first.
+func (c *Client) History(limit int) []ParseRecord {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if limit <= 0 || limit > len(c.history) {
+		limit = len(c.history)
+	}
+	out := make([]ParseRecord, limit)
+	for i := 0; i < limit; i++ {
+		out[i] = c.history[len(c.history)-1-i]
+	}
+	return out
+}
+
+func (c *Client) record(rec ParseRecord) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rec.Timestamp = time.Now()
+	c.history = append(c.history, rec)
+	if len(c.history) > 500 {
+		c.history = c.history[len(c.history)-500:]
+	}
+}
@@ -0,0 +1,61 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func withTestServer(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	prev := openRouterURL
+	openRouterURL = srv.URL
+	t.Cleanup(func() { openRouterURL = prev })
+}
+
+func TestParseSuccess(t *testing.T) {
+	withTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		todos, _ := json.Marshal([]ParsedTodo{{Text: "buy milk"}})
+		resp := map[string]any{
+			"choices": []map[string]any{
+				{"message": map[string]string{"content": string(todos)}},
+			},
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	c := NewClient("test-key")
+	todos, err := c.Parse(context.Background(), 1, "remind me to buy milk")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(todos) != 1 || todos[0].Text != "buy milk" {
+		t.Fatalf("Parse todos = %+v, want a single buy-milk todo", todos)
+	}
+
+	hist := c.History(10)
+	if len(hist) != 1 || hist[0].Err != "" || len(hist[0].Todos) != 1 {
+		t.Fatalf("History = %+v, want one successful record", hist)
+	}
+}
+
+func TestParseFailureRecordsError(t *testing.T) {
+	withTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	c := NewClient("test-key")
+	if _, err := c.Parse(context.Background(), 1, "remind me to buy milk"); err == nil {
+		t.Fatal("Parse: want error on 500 response, got nil")
+	}
+
+	hist := c.History(10)
+	if len(hist) != 1 || hist[0].Err == "" {
+		t.Fatalf("History = %+v, want one failed record", hist)
+	}
+}
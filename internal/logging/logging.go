@@ -0,0 +1,77 @@
+// Package logging builds the bot's zerolog.Logger, selecting between a
+// console writer, JSON-to-stdout, and a Google Cloud Logging sink based
+// on environment configuration.
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"github.com/rs/zerolog"
+)
+
+const cloudLogName = "todo-bot"
+
+// Logger wraps a zerolog.Logger along with any cloud resources it owns,
+// so callers can flush them on shutdown.
+type Logger struct {
+	zerolog.Logger
+	gcpClient *logging.Client
+}
+
+// New builds a Logger according to the LOG_FORMAT and GCP_PROJECT_ID
+// environment variables. LOG_FORMAT of "json" writes structured JSON to
+// stdout; anything else uses a human-readable console writer. When
+// GCP_PROJECT_ID is set, log output is additionally multiplexed to a
+// Google Cloud Logging sink named "todo-bot".
+func New() (*Logger, error) {
+	zerolog.TimeFieldFormat = time.RFC3339
+
+	var writer io.Writer = os.Stdout
+	if os.Getenv("LOG_FORMAT") != "json" {
+		writer = zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
+	}
+
+	var gcpClient *logging.Client
+	if projectID := os.Getenv("GCP_PROJECT_ID"); projectID != "" {
+		client, err := logging.NewClient(context.Background(), projectID)
+		if err != nil {
+			return nil, fmt.Errorf("create gcp logging client: %w", err)
+		}
+		gcpClient = client
+		writer = io.MultiWriter(writer, &cloudWriter{logger: client.Logger(cloudLogName)})
+	}
+
+	level := zerolog.InfoLevel
+	if os.Getenv("DEBUG") == "true" {
+		level = zerolog.DebugLevel
+	}
+
+	zl := zerolog.New(writer).Level(level).With().Timestamp().Logger()
+	return &Logger{Logger: zl, gcpClient: gcpClient}, nil
+}
+
+// Close flushes and closes the Cloud Logging client, if one was created.
+// It is safe to call on a Logger built without GCP configured.
+func (l *Logger) Close() error {
+	if l.gcpClient == nil {
+		return nil
+	}
+	return l.gcpClient.Close()
+}
+
+// cloudWriter adapts a Cloud Logging logger to io.Writer so zerolog can
+// multiplex entries to it alongside stderr/stdout.
+type cloudWriter struct {
+	logger *logging.Logger
+}
+
+func (w *cloudWriter) Write(p []byte) (int, error) {
+	w.logger.Log(logging.Entry{Payload: string(bytes.TrimRight(p, "\n"))})
+	return len(p), nil
+}
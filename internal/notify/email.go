@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+)
+
+// defaultSMTPPort is assumed when host has no port of its own.
+const defaultSMTPPort = "587"
+
+// EmailNotifier sends messages as plain-text email via SMTP.
+type EmailNotifier struct {
+	host     string
+	addr     string
+	user     string
+	password string
+	from     string
+	to       []string
+}
+
+// NewEmailNotifier builds an EmailNotifier using the given SMTP
+// credentials. host may include a port (e.g. "smtp.example.com:465");
+// if it doesn't, port 587 is assumed.
+func NewEmailNotifier(host, user, password, from string, to []string) *EmailNotifier {
+	hostname, _, err := net.SplitHostPort(host)
+	addr := host
+	if err != nil {
+		hostname = host
+		addr = net.JoinHostPort(host, defaultSMTPPort)
+	}
+
+	return &EmailNotifier{
+		host:     hostname,
+		addr:     addr,
+		user:     user,
+		password: password,
+		from:     from,
+		to:       to,
+	}
+}
+
+// Notify sends message as an email to all configured recipients.
+func (e *EmailNotifier) Notify(ctx context.Context, message string) error {
+	auth := smtp.PlainAuth("", e.user, e.password, e.host)
+
+	body := fmt.Sprintf("Subject: TODO Bot Notification\r\n\r\n%s\r\n", message)
+
+	if err := smtp.SendMail(e.addr, auth, e.from, e.to, []byte(body)); err != nil {
+		return fmt.Errorf("send email notification: %w", err)
+	}
+	return nil
+}
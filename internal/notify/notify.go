@@ -0,0 +1,41 @@
+// Package notify dispatches bot events (todo confirmations, sheet-sync
+// failures, batch summaries) to one or more configured channels.
+package notify
+
+import "context"
+
+// Notifier delivers a plain-text message to whatever channel it wraps.
+type Notifier interface {
+	Notify(ctx context.Context, message string) error
+}
+
+// Composite fans a message out to every wrapped Notifier, collecting
+// (but not stopping on) individual failures.
+type Composite struct {
+	notifiers []Notifier
+}
+
+// NewComposite builds a Composite from the given notifiers, skipping any
+// nil entries so callers can pass conditionally-constructed notifiers
+// directly.
+func NewComposite(notifiers ...Notifier) *Composite {
+	c := &Composite{}
+	for _, n := range notifiers {
+		if n != nil {
+			c.notifiers = append(c.notifiers, n)
+		}
+	}
+	return c
+}
+
+// Notify sends message to every configured notifier, returning the last
+// error encountered (if any) after attempting delivery to all of them.
+func (c *Composite) Notify(ctx context.Context, message string) error {
+	var lastErr error
+	for _, n := range c.notifiers {
+		if err := n.Notify(ctx, message); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
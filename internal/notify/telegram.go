@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TelegramNotifier sends messages via the Telegram Bot API to a fixed
+// chat, independent of the bot's own long-polling handler.
+type TelegramNotifier struct {
+	token      string
+	chatID     string
+	httpClient *http.Client
+}
+
+// NewTelegramNotifier builds a TelegramNotifier that posts to chatID
+// using the given bot token.
+func NewTelegramNotifier(token, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{
+		token:      token,
+		chatID:     chatID,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// Notify sends message as a Telegram chat message.
+func (t *TelegramNotifier) Notify(ctx context.Context, message string) error {
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.token)
+
+	form := url.Values{}
+	form.Set("chat_id", t.chatID)
+	form.Set("text", message)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("build telegram notify request: %w", err)
+	}
+	req.URL.RawQuery = form.Encode()
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send telegram notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telegram notification failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
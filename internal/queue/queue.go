@@ -0,0 +1,199 @@
+// Package queue persists todos and their retry state in a local SQLite
+// database.
+package queue
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Todo is a single queued item awaiting sync to the sheet.
+type Todo struct {
+	ID         int64     `json:"id"`
+	ChatID     int64     `json:"chat_id"`
+	Text       string    `json:"text"`
+	Status     string    `json:"status"`
+	RetryCount int       `json:"retry_count"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Manager owns the SQLite connection backing the todo queue.
+type Manager struct {
+	db *sql.DB
+}
+
+// NewManager opens (creating if necessary) the SQLite database at path
+// and ensures the schema is up to date.
+func NewManager(path string) (*Manager, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	m := &Manager{db: db}
+	if err := m.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate database: %w", err)
+	}
+	return m, nil
+}
+
+func (m *Manager) migrate() error {
+	_, err := m.db.Exec(`
+		CREATE TABLE IF NOT EXISTS todos (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			chat_id INTEGER NOT NULL,
+			text TEXT NOT NULL,
+			status TEXT NOT NULL DEFAULT 'pending',
+			retry_count INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS telegram_offsets (
+			name TEXT PRIMARY KEY,
+			offset INTEGER NOT NULL
+		);
+	`)
+	return err
+}
+
+// Offset returns the last acknowledged Telegram update offset for name,
+// or 0 if none has been recorded yet.
+func (m *Manager) Offset(name string) (int64, error) {
+	var offset int64
+	err := m.db.QueryRow(`SELECT offset FROM telegram_offsets WHERE name = ?`, name).Scan(&offset)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read offset %q: %w", name, err)
+	}
+	return offset, nil
+}
+
+// SetOffset persists the last acknowledged Telegram update offset for
+// name.
+func (m *Manager) SetOffset(name string, offset int64) error {
+	_, err := m.db.Exec(`
+		INSERT INTO telegram_offsets (name, offset) VALUES (?, ?)
+		ON CONFLICT(name) DO UPDATE SET offset = excluded.offset
+	`, name, offset)
+	if err != nil {
+		return fmt.Errorf("set offset %q: %w", name, err)
+	}
+	return nil
+}
+
+// Close releases the underlying database connection.
+func (m *Manager) Close() error {
+	return m.db.Close()
+}
+
+// List returns all todos for a chat, or every todo if chatID is zero.
+func (m *Manager) List(chatID int64) ([]Todo, error) {
+	query := `SELECT id, chat_id, text, status, retry_count, created_at, updated_at FROM todos`
+	args := []any{}
+	if chatID != 0 {
+		query += ` WHERE chat_id = ?`
+		args = append(args, chatID)
+	}
+	query += ` ORDER BY id DESC`
+
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list todos: %w", err)
+	}
+	defer rows.Close()
+
+	var todos []Todo
+	for rows.Next() {
+		var t Todo
+		if err := rows.Scan(&t.ID, &t.ChatID, &t.Text, &t.Status, &t.RetryCount, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan todo: %w", err)
+		}
+		todos = append(todos, t)
+	}
+	return todos, rows.Err()
+}
+
+// Get returns a single todo by ID.
+func (m *Manager) Get(id int64) (Todo, error) {
+	var t Todo
+	err := m.db.QueryRow(
+		`SELECT id, chat_id, text, status, retry_count, created_at, updated_at FROM todos WHERE id = ?`, id,
+	).Scan(&t.ID, &t.ChatID, &t.Text, &t.Status, &t.RetryCount, &t.CreatedAt, &t.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return Todo{}, fmt.Errorf("todo %d not found", id)
+	}
+	if err != nil {
+		return Todo{}, fmt.Errorf("get todo %d: %w", id, err)
+	}
+	return t, nil
+}
+
+// Create inserts a new pending todo and returns it with its assigned ID.
+func (m *Manager) Create(chatID int64, text string) (Todo, error) {
+	now := time.Now()
+	res, err := m.db.Exec(
+		`INSERT INTO todos (chat_id, text, status, retry_count, created_at, updated_at) VALUES (?, ?, 'pending', 0, ?, ?)`,
+		chatID, text, now, now,
+	)
+	if err != nil {
+		return Todo{}, fmt.Errorf("create todo: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return Todo{}, fmt.Errorf("create todo: %w", err)
+	}
+	return Todo{ID: id, ChatID: chatID, Text: text, Status: "pending", CreatedAt: now, UpdatedAt: now}, nil
+}
+
+// Update overwrites both the text and the status of an existing todo.
+func (m *Manager) Update(id int64, text, status string) error {
+	_, err := m.db.Exec(
+		`UPDATE todos SET text = ?, status = ?, updated_at = ? WHERE id = ?`,
+		text, status, time.Now(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("update todo %d: %w", id, err)
+	}
+	return nil
+}
+
+// Delete removes a todo from the queue.
+func (m *Manager) Delete(id int64) error {
+	_, err := m.db.Exec(`DELETE FROM todos WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete todo %d: %w", id, err)
+	}
+	return nil
+}
+
+// DeleteByChat removes every todo belonging to chatID, backing the
+// Telegram /clear and /deletebatch commands.
+func (m *Manager) DeleteByChat(chatID int64) error {
+	_, err := m.db.Exec(`DELETE FROM todos WHERE chat_id = ?`, chatID)
+	if err != nil {
+		return fmt.Errorf("delete todos for chat %d: %w", chatID, err)
+	}
+	return nil
+}
+
+// IncrementRetry bumps the retry counter for a todo and returns its new value.
+func (m *Manager) IncrementRetry(id int64) (int, error) {
+	_, err := m.db.Exec(`UPDATE todos SET retry_count = retry_count + 1, updated_at = ? WHERE id = ?`, time.Now(), id)
+	if err != nil {
+		return 0, fmt.Errorf("increment retry for todo %d: %w", id, err)
+	}
+
+	var count int
+	if err := m.db.QueryRow(`SELECT retry_count FROM todos WHERE id = ?`, id).Scan(&count); err != nil {
+		return 0, fmt.Errorf("read retry count for todo %d: %w", id, err)
+	}
+	return count, nil
+}
@@ -0,0 +1,121 @@
+package queue
+
+import "testing"
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	m, err := NewManager(":memory:")
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	t.Cleanup(func() { m.Close() })
+	return m
+}
+
+func TestCreateAndList(t *testing.T) {
+	m := newTestManager(t)
+
+	if _, err := m.Create(1, "buy milk"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := m.Create(2, "walk dog"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	all, err := m.List(0)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("List(0) = %d todos, want 2", len(all))
+	}
+
+	mine, err := m.List(1)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(mine) != 1 || mine[0].Text != "buy milk" {
+		t.Fatalf("List(1) = %+v, want a single buy-milk todo", mine)
+	}
+}
+
+func TestUpdateAndGet(t *testing.T) {
+	m := newTestManager(t)
+
+	todo, err := m.Create(1, "buy milk")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := m.Update(todo.ID, "buy oat milk", "done"); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := m.Get(todo.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Text != "buy oat milk" || got.Status != "done" {
+		t.Fatalf("Get after Update = %+v, want text %q status %q", got, "buy oat milk", "done")
+	}
+}
+
+func TestDeleteByChat(t *testing.T) {
+	m := newTestManager(t)
+
+	if _, err := m.Create(1, "a"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := m.Create(1, "b"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := m.Create(2, "c"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := m.DeleteByChat(1); err != nil {
+		t.Fatalf("DeleteByChat: %v", err)
+	}
+
+	all, err := m.List(0)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(all) != 1 || all[0].ChatID != 2 {
+		t.Fatalf("List after DeleteByChat(1) = %+v, want only chat 2's todo left", all)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	m := newTestManager(t)
+
+	got, err := m.Offset("telegram")
+	if err != nil {
+		t.Fatalf("Offset: %v", err)
+	}
+	if got != 0 {
+		t.Fatalf("Offset of unseen name = %d, want 0", got)
+	}
+
+	if err := m.SetOffset("telegram", 42); err != nil {
+		t.Fatalf("SetOffset: %v", err)
+	}
+	got, err = m.Offset("telegram")
+	if err != nil {
+		t.Fatalf("Offset: %v", err)
+	}
+	if got != 42 {
+		t.Fatalf("Offset after SetOffset(42) = %d, want 42", got)
+	}
+
+	if err := m.SetOffset("telegram", 43); err != nil {
+		t.Fatalf("SetOffset: %v", err)
+	}
+	got, err = m.Offset("telegram")
+	if err != nil {
+		t.Fatalf("Offset: %v", err)
+	}
+	if got != 43 {
+		t.Fatalf("Offset after second SetOffset = %d, want 43", got)
+	}
+}
@@ -0,0 +1,112 @@
+// Package sheets syncs completed todos to a Google Sheet via an Apps
+// Script web app.
+package sheets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FailedSync records a sheet append that failed so it can be replayed later.
+type FailedSync struct {
+	ID        int64     `json:"id"`
+	Payload   []byte    `json:"payload"`
+	Error     string    `json:"error"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Client posts rows to a Google Apps Script web app endpoint.
+type Client struct {
+	scriptURL  string
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	nextID int64
+	failed map[int64]FailedSync
+}
+
+// NewClient creates a Client that posts to the given Apps Script URL.
+func NewClient(scriptURL string) *Client {
+	return &Client{
+		scriptURL:  scriptURL,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		failed:     make(map[int64]FailedSync),
+	}
+}
+
+// Append sends a row to the configured sheet, recording it as failed on
+// error so it can be replayed via Replay.
+func (c *Client) Append(payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal sheet payload: %w", err)
+	}
+
+	if err := c.post(body); err != nil {
+		c.recordFailure(body, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) post(body []byte) error {
+	resp, err := c.httpClient.Post(c.scriptURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("post to sheet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sheet sync failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *Client) recordFailure(payload []byte, cause error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextID++
+	c.failed[c.nextID] = FailedSync{
+		ID:        c.nextID,
+		Payload:   payload,
+		Error:     cause.Error(),
+		Timestamp: time.Now(),
+	}
+}
+
+// Failed returns all sheet syncs currently pending replay.
+func (c *Client) Failed() []FailedSync {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]FailedSync, 0, len(c.failed))
+	for _, f := range c.failed {
+		out = append(out, f)
+	}
+	return out
+}
+
+// Replay retries a previously failed sync by ID, removing it from the
+// failure set on success.
+func (c *Client) Replay(id int64) error {
+	c.mu.Lock()
+	f, ok := c.failed[id]
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no failed sync with id %d", id)
+	}
+
+	if err := c.post(f.Payload); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	delete(c.failed, id)
+	c.mu.Unlock()
+	return nil
+}
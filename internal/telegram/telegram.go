@@ -0,0 +1,333 @@
+// Package telegram implements the bot's Telegram long-polling handler,
+// turning incoming messages into todos via the LLM parser and sheet
+// sync.
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/giovannigabriele/go-todo-bot/internal/llm"
+	"github.com/giovannigabriele/go-todo-bot/internal/notify"
+	"github.com/giovannigabriele/go-todo-bot/internal/queue"
+	"github.com/giovannigabriele/go-todo-bot/internal/sheets"
+)
+
+// offsetName is the key under which the handler's Telegram update
+// offset is persisted via queue.Manager.
+const offsetName = "telegram"
+
+// telegramAPIBase is the root of the Bot API; overridable in tests.
+var telegramAPIBase = "https://api.telegram.org"
+
+const (
+	// longPollLimit bounds how many updates a single getUpdates call
+	// returns while long-polling.
+	longPollLimit = 100
+	// longPollTimeout is how long Telegram holds a getUpdates call open
+	// waiting for a new update before returning an empty result.
+	longPollTimeout = 30 * time.Second
+)
+
+// update is a single recovered Telegram update. Only the fields the
+// catch-up pass needs are decoded.
+type update struct {
+	ID      int64 `json:"update_id"`
+	Message *struct {
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+		Text string `json:"text"`
+	} `json:"message"`
+}
+
+type getUpdatesResponse struct {
+	OK     bool     `json:"ok"`
+	Result []update `json:"result"`
+}
+
+// BatchHandler polls Telegram for updates and turns each message into
+// one or more todos via the LLM client and sheet sync. Updates are
+// processed one at a time; messages are not grouped per chat.
+type BatchHandler struct {
+	token        string
+	llmClient    *llm.Client
+	sheetsClient *sheets.Client
+	queue        *queue.Manager
+	notifier     notify.Notifier
+	totp         *totpGuard
+	httpClient   *http.Client
+}
+
+// ValidateTelegramToken performs a lightweight sanity check on a bot
+// token before attempting to use it.
+func ValidateTelegramToken(token string) error {
+	if token == "" {
+		return fmt.Errorf("telegram token is empty")
+	}
+	if len(token) < 20 {
+		return fmt.Errorf("telegram token is too short to be valid")
+	}
+	return nil
+}
+
+// NewBatchHandler constructs a BatchHandler wired to the given
+// dependencies. notifier receives todo confirmations, sheet-sync
+// failures, and batch summaries instead of the handler sending them to
+// Telegram directly, so they can go to Slack or email as well as, or
+// instead of, a Telegram chat. totpSecret gates destructive
+// commands behind a second factor; if empty, a fresh secret is
+// generated and its provisioning QR is written to disk.
+func NewBatchHandler(token string, llmClient *llm.Client, sheetsClient *sheets.Client, queueManager *queue.Manager, notifier notify.Notifier, totpSecret string) (*BatchHandler, error) {
+	if token == "" {
+		return nil, fmt.Errorf("telegram token is required")
+	}
+
+	guard, err := newTOTPGuard(totpSecret)
+	if err != nil {
+		return nil, fmt.Errorf("set up totp guard: %w", err)
+	}
+
+	return &BatchHandler{
+		token:        token,
+		llmClient:    llmClient,
+		sheetsClient: sheetsClient,
+		queue:        queueManager,
+		notifier:     notifier,
+		totp:         guard,
+		httpClient:   &http.Client{Timeout: longPollTimeout + 5*time.Second},
+	}, nil
+}
+
+// AuthorizeDestructive verifies code against the TOTP guard before a
+// destructive command (e.g. /clear, /deletebatch) is allowed to run for
+// chatID. Commands not in destructiveCommands are always allowed.
+func (h *BatchHandler) AuthorizeDestructive(chatID int64, command, code string) error {
+	if !destructiveCommands[command] {
+		return nil
+	}
+	return h.totp.Verify(chatID, code)
+}
+
+// LastOffset returns the last acknowledged Telegram update offset,
+// persisted across restarts via queue.Manager.
+func (h *BatchHandler) LastOffset() (int64, error) {
+	return h.queue.Offset(offsetName)
+}
+
+// SetOffset persists offset as the last acknowledged Telegram update id.
+func (h *BatchHandler) SetOffset(offset int64) error {
+	return h.queue.SetOffset(offsetName, offset)
+}
+
+// Recover fetches and processes updates missed since the last
+// acknowledged offset, bounded by maxBacklog updates and perMessageTimeout
+// per update, so a restart during a traffic spike can neither stall
+// startup indefinitely nor silently drop an unbounded backlog.
+func (h *BatchHandler) Recover(ctx context.Context, maxBacklog int, perMessageTimeout time.Duration) error {
+	offset, err := h.LastOffset()
+	if err != nil {
+		return fmt.Errorf("load last offset: %w", err)
+	}
+
+	updates, err := h.fetchUpdates(ctx, offset, maxBacklog, 0)
+	if err != nil {
+		return fmt.Errorf("fetch missed updates: %w", err)
+	}
+	if len(updates) == maxBacklog {
+		log.Warn().Int("max_backlog", maxBacklog).Msg("Catch-up stopped at max backlog - remaining updates are left for long-polling")
+	}
+
+	for _, u := range updates {
+		msgCtx, cancel := context.WithTimeout(ctx, perMessageTimeout)
+		err := h.processUpdate(msgCtx, u)
+		cancel()
+		if err != nil {
+			log.Error().Err(err).Int64("update_id", u.ID).Msg("Failed to process update during catch-up")
+			continue
+		}
+		offset = u.ID
+	}
+
+	return h.SetOffset(offset)
+}
+
+// fetchUpdates retrieves updates with id greater than offset, up to
+// limit of them, via the Telegram getUpdates API. Passing offset+1
+// tells Telegram to both fetch from that point and acknowledge
+// everything before it, per the Bot API's getUpdates semantics.
+// timeoutSeconds is forwarded to Telegram's long-poll timeout; callers
+// doing a one-shot catch-up pass should pass 0.
+func (h *BatchHandler) fetchUpdates(ctx context.Context, offset int64, limit, timeoutSeconds int) ([]update, error) {
+	endpoint := fmt.Sprintf("%s/bot%s/getUpdates?offset=%d&limit=%d&timeout=%d", telegramAPIBase, h.token, offset+1, limit, timeoutSeconds)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build getUpdates request: %w", err)
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("call getUpdates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("getUpdates returned status %d", resp.StatusCode)
+	}
+
+	var decoded getUpdatesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("decode getUpdates response: %w", err)
+	}
+	if !decoded.OK {
+		return nil, fmt.Errorf("getUpdates reported failure")
+	}
+	return decoded.Result, nil
+}
+
+// splitCommand splits a Telegram message into a leading command (if
+// any) and the TOTP code that follows it, e.g. "/clear 123456" ->
+// ("/clear", "123456").
+func splitCommand(text string) (command, code string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	command = fields[0]
+	if len(fields) > 1 {
+		code = fields[1]
+	}
+	return command, code
+}
+
+// executeDestructive runs a destructive command that has already passed
+// AuthorizeDestructive.
+func (h *BatchHandler) executeDestructive(chatID int64, command string) error {
+	switch command {
+	case "/clear", "/deletebatch":
+		if err := h.queue.DeleteByChat(chatID); err != nil {
+			return fmt.Errorf("execute %s: %w", command, err)
+		}
+	case "/sheetsadmin":
+		for _, f := range h.sheetsClient.Failed() {
+			if err := h.sheetsClient.Replay(f.ID); err != nil {
+				return fmt.Errorf("execute %s: replay failed sync %d: %w", command, f.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// processUpdate turns a single recovered update into queued todos via
+// the LLM parser, so a restart during a traffic spike replays what
+// would otherwise have been dropped. Destructive commands are gated
+// behind AuthorizeDestructive and executed rather than skipped. Each
+// created todo is synced to the sheet and confirmed (or its failure
+// reported) through the configured notifier.
+func (h *BatchHandler) processUpdate(ctx context.Context, u update) error {
+	if u.Message == nil || u.Message.Text == "" {
+		return nil
+	}
+
+	chatID := u.Message.Chat.ID
+	command, code := splitCommand(u.Message.Text)
+
+	if destructiveCommands[command] {
+		if err := h.AuthorizeDestructive(chatID, command, code); err != nil {
+			return fmt.Errorf("authorize %s: %w", command, err)
+		}
+		return h.executeDestructive(chatID, command)
+	}
+
+	todos, err := h.llmClient.Parse(ctx, chatID, u.Message.Text)
+	if err != nil {
+		log.Warn().Err(err).Int64("chat_id", chatID).Msg("Failed to parse recovered message, queuing as-is")
+		return h.createAndSync(ctx, chatID, u.Message.Text)
+	}
+
+	for _, t := range todos {
+		if err := h.createAndSync(ctx, chatID, t.Text); err != nil {
+			return err
+		}
+	}
+	if len(todos) > 1 {
+		h.notify(ctx, fmt.Sprintf("Queued %d todos for chat %d", len(todos), chatID))
+	}
+	return nil
+}
+
+// createAndSync queues a single todo, syncs it to the sheet, and
+// notifies the configured channels of the outcome.
+func (h *BatchHandler) createAndSync(ctx context.Context, chatID int64, text string) error {
+	todo, err := h.queue.Create(chatID, text)
+	if err != nil {
+		return fmt.Errorf("queue todo: %w", err)
+	}
+
+	if err := h.sheetsClient.Append(todo); err != nil {
+		log.Warn().Err(err).Int64("todo_id", todo.ID).Msg("Failed to sync todo to sheet, queued for replay")
+		h.notify(ctx, fmt.Sprintf("Sheet sync failed for todo %d (%q): %v", todo.ID, todo.Text, err))
+		return nil
+	}
+
+	h.notify(ctx, fmt.Sprintf("Added todo: %s", todo.Text))
+	return nil
+}
+
+// Start begins long-polling Telegram for updates until ctx is cancelled.
+// Recover should be called before Start so the bot catches up on
+// whatever it missed before entering steady-state polling.
+func (h *BatchHandler) Start(ctx context.Context) error {
+	log.Info().Msg("Telegram batch handler started")
+	h.notify(ctx, "TODO bot started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		offset, err := h.LastOffset()
+		if err != nil {
+			return fmt.Errorf("load last offset: %w", err)
+		}
+
+		updates, err := h.fetchUpdates(ctx, offset, longPollLimit, int(longPollTimeout.Seconds()))
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Error().Err(err).Msg("Failed to long-poll Telegram for updates, retrying")
+			continue
+		}
+
+		for _, u := range updates {
+			if err := h.processUpdate(ctx, u); err != nil {
+				log.Error().Err(err).Int64("update_id", u.ID).Msg("Failed to process update")
+				continue
+			}
+			if err := h.SetOffset(u.ID); err != nil {
+				log.Error().Err(err).Int64("update_id", u.ID).Msg("Failed to persist offset")
+			}
+		}
+	}
+}
+
+// notify forwards message to the configured notifier, if any, logging
+// (but not failing on) delivery errors.
+func (h *BatchHandler) notify(ctx context.Context, message string) {
+	if h.notifier == nil {
+		return
+	}
+	if err := h.notifier.Notify(ctx, message); err != nil {
+		log.Error().Err(err).Msg("Failed to dispatch notification")
+	}
+}
@@ -0,0 +1,125 @@
+package telegram
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	totpIssuer      = "GoTodoBot"
+	totpAccountName = "admin"
+	totpRateLimit   = 5
+	totpRateWindow  = 5 * time.Minute
+	totpQRPath      = "totp-qr.png"
+)
+
+// destructiveCommands lists the Telegram commands that require a valid
+// TOTP code before they are allowed to run.
+var destructiveCommands = map[string]bool{
+	"/clear":       true,
+	"/deletebatch": true,
+	"/sheetsadmin": true,
+}
+
+// totpGuard verifies 6-digit TOTP codes before destructive commands run,
+// rate-limiting attempts per chat to blunt brute force.
+type totpGuard struct {
+	secret string
+
+	mu       sync.Mutex
+	attempts map[int64][]time.Time
+}
+
+// newTOTPGuard builds a guard around secret. If secret is empty, a new
+// one is generated, its provisioning URL is logged, and a scannable QR
+// code is written to disk so the operator can enroll it in an
+// authenticator app.
+func newTOTPGuard(secret string) (*totpGuard, error) {
+	if secret == "" {
+		generated, err := provisionTOTPSecret()
+		if err != nil {
+			return nil, fmt.Errorf("provision totp secret: %w", err)
+		}
+		secret = generated
+	}
+	return &totpGuard{secret: secret, attempts: make(map[int64][]time.Time)}, nil
+}
+
+func provisionTOTPSecret() (string, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      totpIssuer,
+		AccountName: totpAccountName,
+	})
+	if err != nil {
+		return "", fmt.Errorf("generate totp key: %w", err)
+	}
+
+	log.Warn().Str("provisioning_url", key.URL()).Msg("No TOTP secret configured - scan this QR code to enable destructive-command auth")
+
+	img, err := key.Image(256, 256)
+	if err != nil {
+		return "", fmt.Errorf("render totp qr: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", fmt.Errorf("encode totp qr: %w", err)
+	}
+	if err := os.WriteFile(totpQRPath, buf.Bytes(), 0o600); err != nil {
+		return "", fmt.Errorf("write totp qr: %w", err)
+	}
+	log.Warn().Str("path", totpQRPath).Msg("TOTP provisioning QR code written to disk")
+
+	return key.Secret(), nil
+}
+
+// Verify checks code for chatID, consulting a per-chat rate limit before
+// the TOTP algorithm itself so a locked-out chat never reaches it.
+func (g *totpGuard) Verify(chatID int64, code string) error {
+	if !g.allow(chatID) {
+		return fmt.Errorf("too many TOTP attempts for chat %d, try again later", chatID)
+	}
+
+	ok, err := totp.ValidateCustom(code, g.secret, time.Now(), totp.ValidateOpts{
+		Period:    30,
+		Skew:      1,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return fmt.Errorf("validate totp code: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("invalid TOTP code")
+	}
+	return nil
+}
+
+func (g *totpGuard) allow(chatID int64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-totpRateWindow)
+
+	recent := g.attempts[chatID][:0]
+	for _, t := range g.attempts[chatID] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= totpRateLimit {
+		g.attempts[chatID] = recent
+		return false
+	}
+
+	g.attempts[chatID] = append(recent, now)
+	return true
+}
@@ -0,0 +1,193 @@
+// Package web exposes an admin REST API for inspecting and managing the
+// todo queue, retry state, sheet sync failures, and LLM parse history.
+package web
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/giovannigabriele/go-todo-bot/internal/llm"
+	"github.com/giovannigabriele/go-todo-bot/internal/queue"
+	"github.com/giovannigabriele/go-todo-bot/internal/sheets"
+)
+
+// Server holds the dependencies needed to serve the admin API.
+type Server struct {
+	queue    *queue.Manager
+	sheets   *sheets.Client
+	llm      *llm.Client
+	user     string
+	password string
+}
+
+// NewServer builds a Server backed by the given dependencies. If both user
+// and password are empty, Basic Auth is not enforced.
+func NewServer(queueManager *queue.Manager, sheetsClient *sheets.Client, llmClient *llm.Client, user, password string) *Server {
+	return &Server{
+		queue:    queueManager,
+		sheets:   sheetsClient,
+		llm:      llmClient,
+		user:     user,
+		password: password,
+	}
+}
+
+// Handler returns the admin API's http.Handler, with Basic Auth applied.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/todos", s.handleTodos)
+	mux.HandleFunc("/api/todos/", s.handleTodoByID)
+	mux.HandleFunc("/api/sheets/failed", s.handleFailedSyncs)
+	mux.HandleFunc("/api/sheets/replay/", s.handleReplaySync)
+	mux.HandleFunc("/api/llm/history", s.handleParseHistory)
+
+	return s.basicAuth(mux)
+}
+
+func (s *Server) basicAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.user == "" && s.password == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		user, pass, ok := r.BasicAuth()
+		userMatch := subtle.ConstantTimeCompare([]byte(user), []byte(s.user)) == 1
+		passMatch := subtle.ConstantTimeCompare([]byte(pass), []byte(s.password)) == 1
+		if !ok || !userMatch || !passMatch {
+			w.Header().Set("WWW-Authenticate", `Basic realm="admin"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleTodos(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		todos, err := s.queue.List(0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, todos)
+	case http.MethodPost:
+		var req struct {
+			ChatID int64  `json:"chat_id"`
+			Text   string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		todo, err := s.queue.Create(req.ChatID, req.Text)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := s.sheets.Append(todo); err != nil {
+			log.Printf("sync todo %d to sheet: %v", todo.ID, err)
+		}
+		writeJSON(w, todo)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleTodoByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Path[len("/api/todos/"):], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid todo id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		var req struct {
+			Text   *string `json:"text"`
+			Status *string `json:"status"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		existing, err := s.queue.Get(id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if req.Text != nil {
+			existing.Text = *req.Text
+		}
+		if req.Status != nil {
+			existing.Status = *req.Status
+		}
+
+		if err := s.queue.Update(id, existing.Text, existing.Status); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if err := s.queue.Delete(id); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleFailedSyncs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, s.sheets.Failed())
+}
+
+func (s *Server) handleReplaySync(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.URL.Path[len("/api/sheets/replay/"):], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid sync id", http.StatusBadRequest)
+		return
+	}
+	if err := s.sheets.Replay(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleParseHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit := 50
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil {
+			limit = n
+		}
+	}
+	writeJSON(w, s.llm.History(limit))
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,91 @@
+package web
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/giovannigabriele/go-todo-bot/internal/llm"
+	"github.com/giovannigabriele/go-todo-bot/internal/queue"
+	"github.com/giovannigabriele/go-todo-bot/internal/sheets"
+)
+
+func newTestServer(t *testing.T, user, password string) (*Server, *queue.Manager) {
+	t.Helper()
+	q, err := queue.NewManager(":memory:")
+	if err != nil {
+		t.Fatalf("queue.NewManager: %v", err)
+	}
+	t.Cleanup(func() { q.Close() })
+
+	s := NewServer(q, sheets.NewClient(""), llm.NewClient(""), user, password)
+	return s, q
+}
+
+func TestBasicAuthBypassedWhenUnconfigured(t *testing.T) {
+	s, _ := newTestServer(t, "", "")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/todos", nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestBasicAuthRejectsWrongCredentials(t *testing.T) {
+	s, _ := newTestServer(t, "admin", "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/todos", nil)
+	req.SetBasicAuth("admin", "wrong")
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestBasicAuthAcceptsCorrectCredentials(t *testing.T) {
+	s, _ := newTestServer(t, "admin", "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/todos", nil)
+	req.SetBasicAuth("admin", "secret")
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestHandleTodoByIDMergesPartialUpdate(t *testing.T) {
+	s, q := newTestServer(t, "", "")
+
+	todo, err := q.Create(1, "buy milk")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/todos/"+strconv.FormatInt(todo.ID, 10), bytes.NewBufferString(`{"status":"done"}`))
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	got, err := q.Get(todo.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Text != "buy milk" {
+		t.Fatalf("Text after status-only PUT = %q, want unchanged %q", got.Text, "buy milk")
+	}
+	if got.Status != "done" {
+		t.Fatalf("Status after PUT = %q, want %q", got.Status, "done")
+	}
+}